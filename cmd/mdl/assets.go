@@ -0,0 +1,50 @@
+package main
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+)
+
+// assets bundles the static files needed to render and serve diagrams
+// without a network connection: mermaid.min.js for air-gapped builds and
+// livereload.js for the serve command. Run scripts/vendor-assets.sh (see
+// assets/README.md) to populate them with the real upstream bundles
+// before using --offline, --renderer=chromedp or serve's LiveReload
+// client; the repo ships them unvendored so it doesn't carry large
+// generated JS in source control.
+//
+//go:embed assets/mermaid.min.js assets/livereload.js
+var assets embed.FS
+
+// vendorPlaceholder is the marker comment scripts/vendor-assets.sh
+// overwrites; its presence means the real bundle hasn't been vendored.
+var vendorPlaceholder = []byte("vendoring target")
+
+// mermaidJS returns the embedded copy of mermaid.min.js, or an error
+// directing the caller to run scripts/vendor-assets.sh if it hasn't been
+// vendored yet.
+func mermaidJS() ([]byte, error) {
+	return readVendoredAsset("assets/mermaid.min.js")
+}
+
+// livereloadJS returns the embedded copy of the LiveReload client
+// library, or an error directing the caller to run
+// scripts/vendor-assets.sh if it hasn't been vendored yet.
+func livereloadJS() ([]byte, error) {
+	return readVendoredAsset("assets/livereload.js")
+}
+
+// readVendoredAsset reads an embedded asset and rejects the unvendored
+// placeholder so callers fail loudly instead of silently shipping a
+// no-op file.
+func readVendoredAsset(name string) ([]byte, error) {
+	b, err := assets.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	if bytes.Contains(b, vendorPlaceholder) {
+		return nil, fmt.Errorf("%s is not vendored: run scripts/vendor-assets.sh (see cmd/mdl/assets/README.md)", name)
+	}
+	return b, nil
+}
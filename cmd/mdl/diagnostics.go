@@ -0,0 +1,71 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goa.design/model/mdl"
+)
+
+// placeholderTemplate replaces the broken-diagram SVG Mermaid would
+// otherwise bake into the page with a readable diagnostic panel, used in
+// --keep-going mode for views whose Mermaid source fails to parse.
+const placeholderTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+	<meta charset="utf-8">
+	<title>{{ .Title }}</title>
+	<style>
+		body { padding: 10px; font-family: Arial; }
+		.title { font-size: 120%; font-weight: bold; padding-bottom: 1em; }
+		.diagnostic {
+			border: 2px solid #c0392b;
+			background: #fdecea;
+			color: #c0392b;
+			padding: 1em;
+			font-family: monospace;
+			white-space: pre-wrap;
+		}
+	</style>
+</head>
+<body>
+	<div class="title">{{ .Title }}</div>
+	<div class="diagnostic">Mermaid source failed to parse:
+{{ .Message }}</div>
+</body>
+</html>
+`
+
+var placeholderTmpl = template.Must(template.New("placeholder").Parse(placeholderTemplate))
+
+// placeholderData is the data structure used to render placeholderTmpl.
+type placeholderData struct {
+	Title   string
+	Message string
+}
+
+// writePlaceholder writes a diagnostic placeholder page for key in out,
+// reporting parseErr in place of the view's diagram.
+func writePlaceholder(out, key, title string, parseErr error) error {
+	f, err := os.Create(filepath.Join(out, key+".html"))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return placeholderTmpl.Execute(f, &placeholderData{Title: title, Message: parseErr.Error()})
+}
+
+// summarizeParseErrors formats parseErrs as a one-line-per-view table
+// suitable for CI output and returns it as an error, failing the build.
+func summarizeParseErrors(parseErrs []*mdl.ParseError) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d view(s) failed to parse:\n", len(parseErrs))
+	for _, e := range parseErrs {
+		fmt.Fprintf(&b, "  %s\n", e.Error())
+	}
+	return errors.New(strings.TrimRight(b.String(), "\n"))
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// liveReloadServer implements enough of the LiveReload v7 protocol
+// (http://livereload.com/api/protocol/) for the bundled livereload.js
+// client to connect and receive "reload" commands.
+type liveReloadServer struct {
+	upgrader websocket.Upgrader
+	mu       sync.Mutex
+	conns    map[*websocket.Conn]bool
+}
+
+// newLiveReloadServer returns a liveReloadServer with no connections yet.
+// It accepts WebSocket connections from any origin, since the pages that
+// load livereload.js are served from another port (or from file://).
+func newLiveReloadServer() *liveReloadServer {
+	return &liveReloadServer{
+		upgrader: websocket.Upgrader{CheckOrigin: func(*http.Request) bool { return true }},
+		conns:    make(map[*websocket.Conn]bool),
+	}
+}
+
+// ListenAndServe accepts LiveReload WebSocket connections on addr and
+// serves the embedded livereload.js client at /livereload.js.
+func (s *liveReloadServer) ListenAndServe(addr string) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/livereload", s.handle)
+	mux.HandleFunc("/livereload.js", func(w http.ResponseWriter, r *http.Request) {
+		js, err := livereloadJS()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/javascript")
+		w.Write(js)
+	})
+	return http.ListenAndServe(addr, mux)
+}
+
+// handle upgrades the connection, performs the "hello" handshake and keeps
+// the client registered until it disconnects.
+func (s *liveReloadServer) handle(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+	hello := map[string]any{
+		"command":    "hello",
+		"protocols":  []string{"http://livereload.com/protocols/official-7"},
+		"serverName": "mdl",
+	}
+	if err := conn.WriteJSON(hello); err != nil {
+		conn.Close()
+		return
+	}
+
+	s.mu.Lock()
+	s.conns[conn] = true
+	s.mu.Unlock()
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			s.mu.Lock()
+			delete(s.conns, conn)
+			s.mu.Unlock()
+			conn.Close()
+			return
+		}
+	}
+}
+
+// Reload broadcasts a "reload" command for the given view path to every
+// connected client.
+func (s *liveReloadServer) Reload(path string) {
+	msg := map[string]any{
+		"command": "reload",
+		"path":    path,
+		"liveCSS": true,
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for conn := range s.conns {
+		conn.WriteJSON(msg)
+	}
+}
@@ -0,0 +1,91 @@
+// Command mdl generates and renders the diagrams described by a Go design
+// package that uses the goa.design/model DSL.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"goa.design/model/mdl"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "gen":
+		fs := flag.NewFlagSet("gen", flag.ExitOnError)
+		out := fs.String("out", "", "output directory for the generated JSON views")
+		debug := fs.Bool("debug", false, "enable debug output")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 || *out == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+		if err := gen(fs.Arg(0), *out, *debug); err != nil {
+			fatal(err)
+		}
+
+	case "render":
+		fs := flag.NewFlagSet("render", flag.ExitOnError)
+		out := fs.String("out", "", "output directory for the generated JSON views and rendered pages")
+		config := fs.String("config", "", "Mermaid config JSON passed through to mermaidAPI.initialize")
+		format := fs.String("format", "", "also export each view as svg, png or pdf")
+		theme := fs.String("theme", "", "theme to render with: neutral, dark, forest, all, or a path to a custom theme directory")
+		layout := fs.String("layout", mdl.LayoutDefault, "flowchart layout engine: default or elk")
+		renderer := fs.String("renderer", "mmdc", "headless renderer backend used for --format export and parse validation: mmdc or chromedp")
+		noLivereload := fs.Bool("no-livereload", false, "omit the LiveReload script tag from rendered pages")
+		offline := fs.Bool("offline", false, "serve mermaid.min.js from a local vendored copy instead of the jsdelivr CDN (run scripts/vendor-assets.sh first)")
+		keepGoing := fs.Bool("keep-going", false, "on parse errors (requires --format), emit a diagnostic placeholder page instead of failing the build")
+		debug := fs.Bool("debug", false, "enable debug output")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 || *out == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+		if err := render(fs.Arg(0), *config, *out, *format, *theme, *layout, *renderer, *noLivereload, *offline, *keepGoing, *debug); err != nil {
+			fatal(err)
+		}
+
+	case "serve":
+		fs := flag.NewFlagSet("serve", flag.ExitOnError)
+		out := fs.String("out", "", "output directory for the generated JSON views and rendered pages")
+		config := fs.String("config", "", "Mermaid config JSON passed through to mermaidAPI.initialize")
+		port := fs.Int("port", 8080, "port to serve rendered views on")
+		theme := fs.String("theme", "", "theme to render with: neutral, dark, forest, or a path to a custom theme directory (\"all\" is not supported)")
+		layout := fs.String("layout", mdl.LayoutDefault, "flowchart layout engine: default or elk")
+		noLivereload := fs.Bool("no-livereload", false, "disable the file watcher and LiveReload server")
+		offline := fs.Bool("offline", false, "serve mermaid.min.js from a local vendored copy instead of the jsdelivr CDN (run scripts/vendor-assets.sh first)")
+		debug := fs.Bool("debug", false, "enable debug output")
+		fs.Parse(os.Args[2:])
+		if fs.NArg() != 1 || *out == "" {
+			fs.Usage()
+			os.Exit(1)
+		}
+		if err := serve(fs.Arg(0), *config, *out, *theme, *layout, *port, *noLivereload, *offline, *debug); err != nil {
+			fatal(err)
+		}
+
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, `usage: mdl <command> [options] <package>
+
+commands:
+  gen      generate JSON views from a Go design package
+  render   generate JSON views and render static HTML (and optionally SVG/PNG/PDF)
+  serve    render and serve views, rebuilding and live-reloading on source changes`)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "mdl:", err)
+	os.Exit(1)
+}
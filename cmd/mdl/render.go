@@ -2,6 +2,7 @@ package main
 
 import (
 	"encoding/json"
+	"fmt"
 	"html/template"
 	"io/ioutil"
 	"os"
@@ -11,7 +12,12 @@ import (
 )
 
 // DefaultTemplate is the template used to render and serve diagrams by
-// default.
+// default, and, since it covers every builtin and custom theme's layout
+// needs, the shared base every theme.Template falls back to when its
+// directory has no index.html.tmpl of its own (see readTheme). It
+// intentionally has no hardcoded Mermaid theme name: theme selection
+// comes entirely from mermaidConfigJS's spread of theme.MermaidInit
+// (mermaid.json) and the --config override.
 const DefaultTemplate = `<!DOCTYPE html>
 <html lang="en">
 <head>
@@ -34,13 +40,15 @@ const DefaultTemplate = `<!DOCTYPE html>
 		</div>
 	</div>
 	<div id="diagram"></div>
-	<script src="http://localhost:35729/livereload.js"></script>
-	<script src="https://cdn.jsdelivr.net/npm/mermaid/dist/mermaid.min.js"></script>
+	{{ if .LiveReload }}<script src="http://localhost:35729/livereload.js"></script>{{ end }}
+	<script src="{{ if .MermaidJS }}{{ .MermaidJS }}{{ else }}https://cdn.jsdelivr.net/npm/mermaid/dist/mermaid.min.js{{ end }}"></script>
+	{{ if eq .Layout "elk" }}<script src="https://cdn.jsdelivr.net/npm/@mermaid-js/layout-elk/dist/mermaid-layout-elk.min.js"></script>{{ end }}
 	<script>
 		var mermaidAPI = mermaid.mermaidAPI;
+		{{ if eq .Layout "elk" }}mermaid.registerLayoutLoaders(mermaidLayoutElk);{{ end }}
 		mermaidAPI.initialize({
 			securityLevel: 'loose',
-			theme: 'neutral',
+			suppressErrorRendering: true,
 			startOnLoad:false{{ if .MermaidConfig }},
 			...{{ .MermaidConfig }}{{ end }}
 		});
@@ -113,6 +121,25 @@ type ViewData struct {
 	MermaidConfig template.JS
 	// CSS rendered inline
 	CSS template.CSS
+	// LiveReload controls whether the page includes the LiveReload
+	// script tag. It is true by default and disabled with
+	// --no-livereload.
+	LiveReload bool
+	// Theme is the name of the theme used to render the page, empty for
+	// the legacy default template.
+	Theme string
+	// Layout is the flowchart layout engine used for this view
+	// (mdl.LayoutDefault or mdl.LayoutELK). It controls whether the page
+	// loads the ELK layout bundle alongside mermaid.min.js.
+	Layout string
+	// Extra holds additional metadata (author, tags, generated-at, ...)
+	// that custom theme templates can render. The built-in CLI leaves
+	// this empty; it exists for callers using the Go API directly.
+	Extra map[string]any
+	// MermaidJS is the URL the page loads mermaid.min.js from. Empty
+	// selects the jsdelivr CDN; --offline sets it to a local path to the
+	// vendored copy instead.
+	MermaidJS template.JS
 }
 
 // indexTmpl is the default Go template used to render views.
@@ -149,28 +176,206 @@ func loadViews(pkg, out string, debug bool) (map[string]*mdl.RenderedView, error
 	return views, nil
 }
 
-// render generates the views and renders static pages from the results.
-func render(pkg, config, out string, debug bool) error {
+// render generates the views, renders static HTML pages from the results
+// and, when format is non-empty, also exports each view as a standalone
+// image ("svg", "png" or "pdf") via a headless Renderer. themeFlag is
+// either empty (legacy default template), the name of a built-in theme,
+// "all" (render every built-in theme, one file per view per theme) or a
+// path to a custom theme directory. layoutFlag is the default flowchart
+// layout engine (mdl.LayoutDefault or mdl.LayoutELK), overridden per view
+// by view.Layout when set. rendererFlag selects the headless backend used
+// for format export and parse validation: "" or "mmdc" for mermaid-cli,
+// "chromedp" for headless Chrome.
+//
+// When format is non-empty, render also validates each view's Mermaid
+// source with the headless Renderer before rendering it. A view that
+// fails to parse is, by default, a fatal error reported as a summary
+// table once every view has been checked; with keepGoing it instead gets
+// a placeholder HTML page with a diagnostic panel, and render continues.
+func render(pkg, config, out, format, themeFlag, layoutFlag, rendererFlag string, noLivereload, offline, keepGoing, debug bool) error {
 	views, err := loadViews(pkg, out, debug)
 	if err != nil {
 		return err
 	}
-	for _, view := range views {
-		f, err := os.Create(filepath.Join(out, view.Key+".html"))
+
+	themeNames := []string{themeFlag}
+	if themeFlag == "all" {
+		themeNames = builtinThemeNames
+	}
+	multi := len(themeNames) > 1
+
+	var mermaidJSPath template.JS
+	if offline {
+		js, err := mermaidJS()
 		if err != nil {
 			return err
 		}
-		data := &ViewData{
-			Title:         view.Title,
-			Description:   view.Description,
-			Version:       view.Version,
-			MermaidSource: template.JS(view.Mermaid),
-			MermaidConfig: template.JS(config),
-			CSS:           template.CSS(DefaultCSS),
+		if err := ioutil.WriteFile(filepath.Join(out, "mermaid.min.js"), js, 0644); err != nil {
+			return err
+		}
+		mermaidJSPath = "mermaid.min.js"
+	}
+
+	var parseErrs []*mdl.ParseError
+	for _, view := range views {
+		layout := view.Layout
+		if layout == "" {
+			layout = layoutFlag
+		}
+		mermaid := mdl.WithFrontMatter(mdl.RewriteLayout(view.Mermaid, layout), view.Title, view.Description, view.Config)
+
+		var configFile string
+		if format != "" {
+			configFile, err = mmdcConfigFile(layout)
+			if err != nil {
+				return err
+			}
+		}
+		var rdr mdl.Renderer
+		if format != "" {
+			rdr, err = newRenderer(rendererFlag, configFile)
+			if err != nil {
+				return err
+			}
+		}
+
+		if format != "" {
+			if _, perr := rdr.Parse(mermaid); perr != nil {
+				pe, ok := perr.(*mdl.ParseError)
+				if !ok {
+					pe = &mdl.ParseError{Message: perr.Error()}
+				}
+				pe.ViewKey = view.Key
+				parseErrs = append(parseErrs, pe)
+				if configFile != "" {
+					os.Remove(configFile)
+				}
+				if keepGoing {
+					if err := writePlaceholder(out, view.Key, view.Title, perr); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+		}
+
+		for _, tn := range themeNames {
+			theme, err := themeFor(tn)
+			if err != nil {
+				return err
+			}
+			filename := view.Key + ".html"
+			if multi {
+				filename = view.Key + "." + theme.Name + ".html"
+			}
+			f, err := os.Create(filepath.Join(out, filename))
+			if err != nil {
+				return err
+			}
+			configJS, err := mermaidConfigJS(theme, config)
+			if err != nil {
+				f.Close()
+				return err
+			}
+			data := &ViewData{
+				Title:         view.Title,
+				Description:   view.Description,
+				Version:       view.Version,
+				MermaidSource: template.JS(mermaid),
+				MermaidConfig: configJS,
+				CSS:           template.CSS(theme.CSS),
+				LiveReload:    !noLivereload,
+				Theme:         theme.Name,
+				Layout:        layout,
+				MermaidJS:     mermaidJSPath,
+			}
+			err = theme.Template.Execute(f, data)
+			f.Close()
+			if err != nil {
+				return err
+			}
+		}
+
+		if format == "" {
+			continue
+		}
+		res, err := rdr.Render(mermaid, format)
+		if configFile != "" {
+			os.Remove(configFile)
+		}
+		if err != nil {
+			return fmt.Errorf("render %s: %w", view.Key, err)
 		}
-		if err := indexTmpl.Execute(f, data); err != nil {
+		if err := ioutil.WriteFile(filepath.Join(out, view.Key+"."+format), res.Data, 0644); err != nil {
 			return err
 		}
 	}
+
+	if len(parseErrs) > 0 && !keepGoing {
+		return summarizeParseErrors(parseErrs)
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// newRenderer builds the headless Renderer backend selected by name: ""
+// or "mmdc" for mermaid-cli (configFile, if non-empty, is passed through
+// via --configFile), "chromedp" for headless Chrome (configFile is
+// ignored; ELK configuration isn't supported by that backend yet).
+func newRenderer(name, configFile string) (mdl.Renderer, error) {
+	switch name {
+	case "", "mmdc":
+		return newMMDCRenderer(configFile), nil
+	case "chromedp":
+		return newChromedpRenderer()
+	default:
+		return nil, fmt.Errorf("unknown renderer %q, want \"mmdc\" or \"chromedp\"", name)
+	}
+}
+
+// mmdcConfigFile writes a temporary mmdc --configFile enabling the ELK
+// flowchart renderer when layout is mdl.LayoutELK, returning its path, or
+// "" when the default layout is in use. Callers are responsible for
+// removing the returned file.
+func mmdcConfigFile(layout string) (string, error) {
+	if layout != mdl.LayoutELK {
+		return "", nil
+	}
+	f, err := ioutil.TempFile("", "mdl-elk-*.json")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	if _, err := f.WriteString(`{"layout": "elk"}`); err != nil {
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// mermaidConfigJS merges theme's MermaidInit with the --config override
+// (which takes precedence key-for-key) and returns the result as the JSON
+// spread into mermaidAPI.initialize. It returns an empty value when there
+// is nothing to merge, preserving the legacy template's behavior of
+// omitting the spread entirely.
+func mermaidConfigJS(theme *mdl.Theme, config string) (template.JS, error) {
+	init := map[string]any{}
+	for k, v := range theme.MermaidInit {
+		init[k] = v
+	}
+	if config != "" {
+		var override map[string]any
+		if err := json.Unmarshal([]byte(config), &override); err != nil {
+			return "", fmt.Errorf("config: %w", err)
+		}
+		for k, v := range override {
+			init[k] = v
+		}
+	}
+	if len(init) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(init)
+	if err != nil {
+		return "", err
+	}
+	return template.JS(b), nil
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+	"goa.design/model/mdl"
+)
+
+// chromedpHTML is a minimal page that loads the embedded Mermaid runtime
+// and exposes window.mdlRender so chromedp can drive mermaidAPI.render
+// directly, without going through the full view template.
+const chromedpHTML = `<!DOCTYPE html>
+<html><head><script>%s</script></head>
+<body><script>
+var mermaidAPI = mermaid.mermaidAPI;
+mermaidAPI.initialize({startOnLoad:false, securityLevel:'loose'});
+window.mdlRender = function(src, cb) {
+	mermaidAPI.render('mdl', src, function(svg) { cb(svg); });
+};
+</script></body></html>`
+
+// chromedpRenderer renders Mermaid source using a headless Chrome instance
+// driven by chromedp, loading the embedded mermaid.min.js so no network
+// access is required at render time.
+type chromedpRenderer struct {
+	timeout time.Duration
+}
+
+// newChromedpRenderer returns a Renderer backed by headless Chrome.
+func newChromedpRenderer() (mdl.Renderer, error) {
+	return &chromedpRenderer{timeout: 30 * time.Second}, nil
+}
+
+// Parse loads src into the headless page and reports whether mermaidAPI
+// rendered it without throwing.
+func (r *chromedpRenderer) Parse(src string) (*mdl.ParseResult, error) {
+	if _, err := r.renderSVG(src); err != nil {
+		return &mdl.ParseResult{Valid: false}, err
+	}
+	return &mdl.ParseResult{Valid: true}, nil
+}
+
+// Render renders src and returns the resulting image bytes. Only "svg" is
+// supported directly; "png" and "pdf" are produced by chromedp's page
+// screenshot/PDF capture of the rendered SVG.
+func (r *chromedpRenderer) Render(src, format string) (*mdl.RenderResult, error) {
+	svg, err := r.renderSVG(src)
+	if err != nil {
+		return nil, err
+	}
+	switch format {
+	case "svg":
+		return &mdl.RenderResult{Format: format, Data: []byte(svg)}, nil
+	case "png", "pdf":
+		data, err := r.capture(svg, format)
+		if err != nil {
+			return nil, err
+		}
+		return &mdl.RenderResult{Format: format, Data: data}, nil
+	default:
+		return nil, fmt.Errorf("chromedp renderer: unsupported format %q", format)
+	}
+}
+
+// renderSVG navigates to the embedded Mermaid page and calls
+// mermaidAPI.render on src, returning the resulting SVG markup.
+func (r *chromedpRenderer) renderSVG(src string) (string, error) {
+	js, err := mermaidJS()
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var svg string
+	doc := fmt.Sprintf(chromedpHTML, js)
+	err = chromedp.Run(ctx,
+		chromedp.Navigate("data:text/html,"+doc),
+		chromedp.Evaluate(fmt.Sprintf(
+			`new Promise(function(resolve){ window.mdlRender(%q, resolve); })`, src,
+		), &svg),
+	)
+	if err != nil {
+		return "", fmt.Errorf("chromedp: %w", err)
+	}
+	return svg, nil
+}
+
+// capture rasterizes the given SVG into the requested format using
+// chromedp's screenshot and PDF capture.
+func (r *chromedpRenderer) capture(svg, format string) ([]byte, error) {
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+	ctx, cancel = context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var data []byte
+	doc := fmt.Sprintf("<!DOCTYPE html><html><body>%s</body></html>", svg)
+	tasks := chromedp.Tasks{chromedp.Navigate("data:text/html," + doc)}
+	if format == "png" {
+		tasks = append(tasks, chromedp.FullScreenshot(&data, 100))
+	} else {
+		tasks = append(tasks, chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			data, _, err = page.PrintToPDF().Do(ctx)
+			return err
+		}))
+	}
+	if err := chromedp.Run(ctx, tasks); err != nil {
+		return nil, fmt.Errorf("chromedp: %w", err)
+	}
+	return data, nil
+}
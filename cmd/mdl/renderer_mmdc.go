@@ -0,0 +1,97 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"goa.design/model/mdl"
+)
+
+// mmdcLineRe matches the "line N" mermaid includes in the parse error it
+// prints on invalid syntax, e.g. "Parse error on line 3:".
+var mmdcLineRe = regexp.MustCompile(`(?i)\bline (\d+)\b`)
+
+// newParseError builds a *mdl.ParseError from mmdc's combined
+// stdout/stderr, populating Line when the output contains one. ViewKey is
+// left for the caller to fill in.
+func newParseError(msg string) *mdl.ParseError {
+	pe := &mdl.ParseError{Message: msg}
+	if m := mmdcLineRe.FindStringSubmatch(msg); m != nil {
+		pe.Line, _ = strconv.Atoi(m[1])
+	}
+	return pe
+}
+
+// mmdcRenderer renders Mermaid source using mermaid-cli (mmdc) invoked
+// through npx, so it picks up whatever version is cached or installable
+// on the host without a direct Go dependency on the Mermaid runtime.
+type mmdcRenderer struct {
+	// configFile, if set, is passed to mmdc via --configFile.
+	configFile string
+}
+
+// newMMDCRenderer returns a Renderer backed by "npx mmdc". configFile may
+// be empty.
+func newMMDCRenderer(configFile string) mdl.Renderer {
+	return &mmdcRenderer{configFile: configFile}
+}
+
+// Parse shells out to mmdc to check that src is valid Mermaid source. mmdc
+// has no dedicated parse-only mode, so this renders to a throwaway SVG and
+// reports success based on the exit code.
+func (r *mmdcRenderer) Parse(src string) (*mdl.ParseResult, error) {
+	tmp, err := r.render(src, "svg")
+	if err != nil {
+		return &mdl.ParseResult{Valid: false}, newParseError(err.Error())
+	}
+	defer os.Remove(tmp)
+	return &mdl.ParseResult{Valid: true}, nil
+}
+
+// Render renders src to the given format and returns the resulting bytes.
+func (r *mmdcRenderer) Render(src, format string) (*mdl.RenderResult, error) {
+	out, err := r.render(src, format)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(out)
+	data, err := ioutil.ReadFile(out)
+	if err != nil {
+		return nil, err
+	}
+	return &mdl.RenderResult{Format: format, Data: data}, nil
+}
+
+// render writes src to a temporary .mmd file, invokes mmdc to produce the
+// given format and returns the path to the generated output file.
+func (r *mmdcRenderer) render(src, format string) (string, error) {
+	in, err := ioutil.TempFile("", "mdl-*.mmd")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(in.Name())
+	if _, err := in.WriteString(src); err != nil {
+		in.Close()
+		return "", err
+	}
+	in.Close()
+
+	out := in.Name() + "." + format
+	args := []string{"-y", "@mermaid-js/mermaid-cli", "-i", in.Name(), "-o", out}
+	if r.configFile != "" {
+		args = append(args, "--configFile", r.configFile)
+	}
+	cmd := exec.Command("npx", args...)
+	if msg, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("mmdc: %w: %s", err, msg)
+	}
+	if _, err := os.Stat(out); err != nil {
+		return "", fmt.Errorf("mmdc: expected output %s: %w", filepath.Base(out), err)
+	}
+	return out, nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import "testing"
+
+func TestNewParseError(t *testing.T) {
+	cases := []struct {
+		name     string
+		msg      string
+		wantLine int
+	}{
+		{
+			name:     "mermaid parse error with a line number",
+			msg:      "mmdc: exit status 1: Error: Parse error on line 3:\nflowchart TB\n  a -->\n-------^\nExpecting 'NODE_STRING', got 'NEWLINE'",
+			wantLine: 3,
+		},
+		{
+			name:     "no line number in the message",
+			msg:      "mmdc: exit status 127: npx: command not found",
+			wantLine: 0,
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pe := newParseError(c.msg)
+			if pe.Line != c.wantLine {
+				t.Errorf("newParseError(%q).Line = %d, want %d", c.msg, pe.Line, c.wantLine)
+			}
+			if pe.Message != c.msg {
+				t.Errorf("newParseError(%q).Message = %q, want the original message preserved", c.msg, pe.Message)
+			}
+		})
+	}
+}
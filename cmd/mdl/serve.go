@@ -0,0 +1,175 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"goa.design/model/mdl"
+)
+
+// watchDebounce is how long the file watcher waits after the last detected
+// change before regenerating views, so a batch of saves (e.g. gofmt
+// rewriting several files) triggers a single rebuild.
+const watchDebounce = 200 * time.Millisecond
+
+// serve runs loadViews once, serves the rendered HTML for each view on its
+// own "/<viewKey>" URL and, unless noLivereload is set, watches pkg for .go
+// changes: on a change it reruns gen and loadViews and pushes a LiveReload
+// "reload" message for each view whose Mermaid source actually changed.
+// With offline set, mermaid.min.js is served locally from the vendored
+// copy at /mermaid.min.js instead of pages loading it from the CDN.
+// themeFlag is resolved the same way render's is, except "all" isn't
+// supported: serve renders every view with a single theme at a time.
+func serve(pkg, config, out, themeFlag, layoutFlag string, port int, noLivereload, offline, debug bool) error {
+	if themeFlag == "all" {
+		return fmt.Errorf("serve: --theme=all is not supported; serve renders every view with a single theme at a time")
+	}
+	theme, err := themeFor(themeFlag)
+	if err != nil {
+		return err
+	}
+	configJS, err := mermaidConfigJS(theme, config)
+	if err != nil {
+		return err
+	}
+
+	views, err := loadViews(pkg, out, debug)
+	if err != nil {
+		return err
+	}
+
+	var mermaidJSPath template.JS
+	if offline {
+		mermaidJSPath = "/mermaid.min.js"
+	}
+
+	var mu sync.RWMutex
+	var lr *liveReloadServer
+	if !noLivereload {
+		lr = newLiveReloadServer()
+		go func() {
+			if err := lr.ListenAndServe(":35729"); err != nil {
+				log.Println("mdl: serve: livereload:", err)
+			}
+		}()
+		go watch(pkg, debug, func() {
+			newViews, err := loadViews(pkg, out, debug)
+			if err != nil {
+				log.Println("mdl: serve:", err)
+				return
+			}
+			mu.Lock()
+			old := views
+			views = newViews
+			mu.Unlock()
+			for key, view := range newViews {
+				if prev, ok := old[key]; !ok || prev.Mermaid != view.Mermaid {
+					lr.Reload(key)
+				}
+			}
+		})
+	}
+
+	mux := http.NewServeMux()
+	if offline {
+		mux.HandleFunc("/mermaid.min.js", func(w http.ResponseWriter, r *http.Request) {
+			js, err := mermaidJS()
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			w.Header().Set("Content-Type", "application/javascript")
+			w.Write(js)
+		})
+	}
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		key := strings.Trim(r.URL.Path, "/")
+		mu.RLock()
+		view, ok := views[key]
+		mu.RUnlock()
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		layout := view.Layout
+		if layout == "" {
+			layout = layoutFlag
+		}
+		mermaid := mdl.WithFrontMatter(mdl.RewriteLayout(view.Mermaid, layout), view.Title, view.Description, view.Config)
+		data := &ViewData{
+			Title:         view.Title,
+			Description:   view.Description,
+			Version:       view.Version,
+			MermaidSource: template.JS(mermaid),
+			MermaidConfig: configJS,
+			CSS:           template.CSS(theme.CSS),
+			LiveReload:    !noLivereload,
+			Theme:         theme.Name,
+			Layout:        layout,
+			MermaidJS:     mermaidJSPath,
+		}
+		if err := theme.Template.Execute(w, data); err != nil {
+			log.Println("mdl: serve:", err)
+		}
+	})
+
+	addr := ":" + strconv.Itoa(port)
+	log.Printf("mdl: serving %d view(s) on http://localhost%s", len(views), addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// watch walks pkg for .go files, watches them with fsnotify and invokes
+// rebuild at most once per watchDebounce window of changes.
+func watch(pkg string, debug bool, rebuild func()) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		log.Println("mdl: serve: watcher:", err)
+		return
+	}
+	defer w.Close()
+
+	err = filepath.Walk(pkg, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return w.Add(path)
+		}
+		return nil
+	})
+	if err != nil {
+		log.Println("mdl: serve: watcher:", err)
+		return
+	}
+
+	var timer *time.Timer
+	for {
+		select {
+		case ev, ok := <-w.Events:
+			if !ok {
+				return
+			}
+			if !strings.HasSuffix(ev.Name, ".go") {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(watchDebounce, rebuild)
+		case err, ok := <-w.Errors:
+			if !ok {
+				return
+			}
+			log.Println("mdl: serve: watcher:", err)
+		}
+	}
+}
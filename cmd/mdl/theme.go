@@ -0,0 +1,80 @@
+package main
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"goa.design/model/mdl"
+)
+
+//go:embed themes
+var builtinThemes embed.FS
+
+// builtinThemeNames lists the first-party themes shipped alongside mdl,
+// matching Mermaid's own built-in theme set.
+var builtinThemeNames = []string{"neutral", "dark", "forest"}
+
+// defaultTheme wraps the legacy DefaultTemplate/DefaultCSS pair as a Theme
+// so render can treat the unthemed path identically to a named theme. Its
+// MermaidInit pins Mermaid's "neutral" theme, matching the builtin
+// "neutral" theme's mermaid.json, since DefaultTemplate itself no longer
+// hardcodes a theme name.
+func defaultTheme() *mdl.Theme {
+	return &mdl.Theme{Template: indexTmpl, CSS: DefaultCSS, MermaidInit: map[string]any{"theme": "neutral"}}
+}
+
+// themeFor resolves name to a mdl.Theme: "" selects the legacy default
+// template, a name in builtinThemeNames selects the matching embedded
+// theme, and anything else is treated as a path to a directory
+// containing styles.css, an optional mermaid.json and an optional
+// index.html.tmpl (see readTheme).
+func themeFor(name string) (*mdl.Theme, error) {
+	if name == "" {
+		return defaultTheme(), nil
+	}
+	for _, n := range builtinThemeNames {
+		if name == n {
+			sub, err := fs.Sub(builtinThemes, filepath.Join("themes", n))
+			if err != nil {
+				return nil, err
+			}
+			return readTheme(n, sub)
+		}
+	}
+	return readTheme(filepath.Base(name), os.DirFS(name))
+}
+
+// readTheme loads a theme's template, CSS and Mermaid init options from
+// themeFS. index.html.tmpl is optional: a theme directory that only
+// wants to customize the CSS and/or mermaid.json can omit it and fall
+// back to the shared indexTmpl (DefaultTemplate), which is how all three
+// builtin themes work.
+func readTheme(name string, themeFS fs.FS) (*mdl.Theme, error) {
+	tmpl := indexTmpl
+	if tmplSrc, err := fs.ReadFile(themeFS, "index.html.tmpl"); err == nil {
+		tmpl, err = template.New(name).Parse(string(tmplSrc))
+		if err != nil {
+			return nil, fmt.Errorf("theme %s: %w", name, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("theme %s: %w", name, err)
+	}
+	cssSrc, err := fs.ReadFile(themeFS, "styles.css")
+	if err != nil {
+		return nil, fmt.Errorf("theme %s: %w", name, err)
+	}
+	theme := &mdl.Theme{Name: name, Template: tmpl, CSS: string(cssSrc), Assets: themeFS}
+	if b, err := fs.ReadFile(themeFS, "mermaid.json"); err == nil {
+		var init map[string]any
+		if err := json.Unmarshal(b, &init); err != nil {
+			return nil, fmt.Errorf("theme %s: mermaid.json: %w", name, err)
+		}
+		theme.MermaidInit = init
+	}
+	return theme, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+
+	"goa.design/model/mdl"
+)
+
+func TestThemeFor(t *testing.T) {
+	t.Run("empty name selects the legacy default template", func(t *testing.T) {
+		theme, err := themeFor("")
+		if err != nil {
+			t.Fatalf("themeFor(\"\") returned error: %v", err)
+		}
+		if theme.Name != "" || theme.Template == nil || theme.CSS != DefaultCSS {
+			t.Errorf("themeFor(\"\") = %+v, want the default template/CSS pair", theme)
+		}
+	})
+
+	for _, name := range builtinThemeNames {
+		t.Run("builtin theme "+name, func(t *testing.T) {
+			theme, err := themeFor(name)
+			if err != nil {
+				t.Fatalf("themeFor(%q) returned error: %v", name, err)
+			}
+			if theme.Name != name || theme.Template == nil || theme.CSS == "" {
+				t.Errorf("themeFor(%q) = %+v, want a fully loaded builtin theme", name, theme)
+			}
+		})
+	}
+
+	t.Run("unknown path fails", func(t *testing.T) {
+		if _, err := themeFor("/no/such/theme/dir"); err == nil {
+			t.Error("themeFor with a nonexistent directory: got nil error, want one")
+		}
+	})
+}
+
+func TestMermaidConfigJS(t *testing.T) {
+	t.Run("no theme init and no override yields an empty value", func(t *testing.T) {
+		got, err := mermaidConfigJS(&mdl.Theme{}, "")
+		if err != nil {
+			t.Fatalf("mermaidConfigJS returned error: %v", err)
+		}
+		if got != "" {
+			t.Errorf("mermaidConfigJS(theme with no init, \"\") = %q, want empty", got)
+		}
+	})
+
+	t.Run("override takes precedence over theme init", func(t *testing.T) {
+		theme := &mdl.Theme{MermaidInit: map[string]any{"theme": "neutral", "fontFamily": "Arial"}}
+		got, err := mermaidConfigJS(theme, `{"theme":"dark"}`)
+		if err != nil {
+			t.Fatalf("mermaidConfigJS returned error: %v", err)
+		}
+		var merged map[string]any
+		if err := json.Unmarshal([]byte(got), &merged); err != nil {
+			t.Fatalf("mermaidConfigJS returned invalid JSON %q: %v", got, err)
+		}
+		if merged["theme"] != "dark" {
+			t.Errorf("merged config theme = %v, want the --config override to win (\"dark\")", merged["theme"])
+		}
+		if merged["fontFamily"] != "Arial" {
+			t.Errorf("merged config fontFamily = %v, want the theme's value to survive (\"Arial\")", merged["fontFamily"])
+		}
+	})
+
+	t.Run("invalid override JSON is an error", func(t *testing.T) {
+		if _, err := mermaidConfigJS(&mdl.Theme{}, "not json"); err == nil {
+			t.Error("mermaidConfigJS with invalid --config JSON: got nil error, want one")
+		}
+	})
+}
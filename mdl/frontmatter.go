@@ -0,0 +1,110 @@
+package mdl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MermaidConfig is the subset of Mermaid's per-diagram configuration that
+// mdl can apply to a view's Mermaid source: the front-matter theme and
+// look, and the flowchart's layout direction. See
+// https://mermaid.js.org/config/configuration.html#frontmatter-config.
+type MermaidConfig struct {
+	// Theme overrides the Mermaid theme for this diagram only.
+	Theme string
+	// Direction sets the flowchart layout direction (TB, BT, LR, RL).
+	Direction string
+	// HandDrawn selects Mermaid's "handDrawn" look.
+	HandDrawn bool
+}
+
+// WithMermaidConfig is a convenience constructor for use from the design
+// DSL, e.g. mdl.WithMermaidConfig("dark", "LR", false), to set a view's
+// Config field.
+func WithMermaidConfig(theme, direction string, handDrawn bool) *MermaidConfig {
+	return &MermaidConfig{Theme: theme, Direction: direction, HandDrawn: handDrawn}
+}
+
+// WithFrontMatter returns src with a YAML front-matter block prepended,
+// built from title, description and config, and with its flowchart
+// direction rewritten when config.Direction is set. This makes the
+// Mermaid source the browser sees self-describing: it carries its own
+// title and config independent of whatever HTML template wraps it.
+func WithFrontMatter(src, title, description string, config *MermaidConfig) string {
+	src = applyDirection(src, config)
+
+	var body []string
+	if title != "" {
+		body = append(body, "title: "+quoteYAML(title))
+	}
+	if description != "" {
+		body = append(body, "description: "+quoteYAML(description))
+	}
+	if config != nil && (config.Theme != "" || config.HandDrawn) {
+		body = append(body, "config:")
+		if config.Theme != "" {
+			body = append(body, "  theme: "+quoteYAML(config.Theme))
+		}
+		if config.HandDrawn {
+			body = append(body, "  look: handDrawn")
+		}
+	}
+	if len(body) == 0 {
+		return src
+	}
+	return "---\n" + strings.Join(body, "\n") + "\n---\n" + src
+}
+
+// quoteYAML renders s as a YAML double-quoted scalar, escaping the
+// characters that scalar style requires ("\", the closing quote,
+// newlines and tabs) and additionally hex-escaping the backtick, "$" and
+// "<" characters. The result is later embedded in a JS template literal
+// (the rendered page assigns the Mermaid source, front matter included,
+// to a backtick-delimited string before passing it to
+// mermaidAPI.render), so a title or description containing a backtick,
+// "${" or "</script>" must not be able to break out of that literal or
+// the surrounding <script> tag.
+func quoteYAML(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\t':
+			b.WriteString(`\t`)
+		case '`', '$', '<':
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
+
+// applyDirection rewrites the direction token on src's first
+// "flowchart"/"flowchart-elk"/"graph" line when config.Direction is set.
+// flowchart-elk is RewriteLayout's rewritten form of flowchart when the
+// ELK layout engine is selected, so it needs the same direction handling.
+func applyDirection(src string, config *MermaidConfig) string {
+	if config == nil || config.Direction == "" {
+		return src
+	}
+	lines := strings.SplitN(src, "\n", 2)
+	first := strings.Fields(lines[0])
+	if len(first) == 0 || (first[0] != "flowchart" && first[0] != "flowchart-elk" && first[0] != "graph") {
+		return src
+	}
+	if len(first) >= 2 {
+		first[len(first)-1] = config.Direction
+	} else {
+		first = append(first, config.Direction)
+	}
+	lines[0] = strings.Join(first, " ")
+	return strings.Join(lines, "\n")
+}
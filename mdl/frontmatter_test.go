@@ -0,0 +1,106 @@
+package mdl
+
+import "testing"
+
+func TestWithFrontMatter(t *testing.T) {
+	cases := []struct {
+		name        string
+		src         string
+		title       string
+		description string
+		config      *MermaidConfig
+		want        string
+	}{
+		{
+			name: "no metadata is a no-op",
+			src:  "flowchart TB\n  a --> b",
+			want: "flowchart TB\n  a --> b",
+		},
+		{
+			name:  "title with a colon is quoted",
+			src:   "flowchart TB\n  a --> b",
+			title: "Container diagram: Payments",
+			want:  "---\ntitle: \"Container diagram: Payments\"\n---\nflowchart TB\n  a --> b",
+		},
+		{
+			name:  "backtick and ${ are hex-escaped",
+			src:   "flowchart TB\n  a --> b",
+			title: "a`${alert(1)}`",
+			want:  "---\ntitle: \"a\\x60\\x24{alert(1)}\\x60\"\n---\nflowchart TB\n  a --> b",
+		},
+		{
+			name:        "description and config theme",
+			src:         "flowchart TB\n  a --> b",
+			description: "some \"quoted\" text",
+			config:      &MermaidConfig{Theme: "dark"},
+			want:        "---\ndescription: \"some \\\"quoted\\\" text\"\nconfig:\n  theme: \"dark\"\n---\nflowchart TB\n  a --> b",
+		},
+		{
+			name:   "direction override rewrites the flowchart line",
+			src:    "flowchart TB\n  a --> b",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "flowchart LR\n  a --> b",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := WithFrontMatter(c.src, c.title, c.description, c.config)
+			if got != c.want {
+				t.Errorf("WithFrontMatter(%q, %q, %q, %+v) = %q, want %q", c.src, c.title, c.description, c.config, got, c.want)
+			}
+		})
+	}
+}
+
+func TestApplyDirection(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		config *MermaidConfig
+		want   string
+	}{
+		{
+			name: "nil config is a no-op",
+			src:  "flowchart TB\n  a --> b",
+			want: "flowchart TB\n  a --> b",
+		},
+		{
+			name:   "flowchart direction is replaced",
+			src:    "flowchart TB\n  a --> b",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "flowchart LR\n  a --> b",
+		},
+		{
+			name:   "flowchart-elk direction is replaced",
+			src:    "flowchart-elk TB\n  a --> b",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "flowchart-elk LR\n  a --> b",
+		},
+		{
+			name:   "graph direction is replaced",
+			src:    "graph TB\n  a --> b",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "graph LR\n  a --> b",
+		},
+		{
+			name:   "direction is appended when missing",
+			src:    "flowchart\n  a --> b",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "flowchart LR\n  a --> b",
+		},
+		{
+			name:   "non-flowchart sources are untouched",
+			src:    "sequenceDiagram\n  a->>b: hi",
+			config: &MermaidConfig{Direction: "LR"},
+			want:   "sequenceDiagram\n  a->>b: hi",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			got := applyDirection(c.src, c.config)
+			if got != c.want {
+				t.Errorf("applyDirection(%q, %+v) = %q, want %q", c.src, c.config, got, c.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package mdl
+
+import "strings"
+
+// Layout selects the engine Mermaid uses to lay out a flowchart.
+const (
+	// LayoutDefault is Mermaid's built-in dagre layout.
+	LayoutDefault = "default"
+	// LayoutELK selects the ELK-based flowchart layout
+	// (@mermaid-js/layout-elk), which produces better results than
+	// dagre for large graphs such as C4 component/container diagrams.
+	LayoutELK = "elk"
+)
+
+// WithLayout is a convenience constructor for use from the design DSL,
+// e.g. mdl.WithLayout(mdl.LayoutELK), to set a view's Layout field. It
+// passes layout through unchanged, including "" (render and serve only
+// fall back to their --layout flag when view.Layout is exactly "", so
+// normalizing it here to LayoutDefault would pin the view to dagre and
+// it could never again inherit a later --layout=elk run).
+func WithLayout(layout string) string {
+	return layout
+}
+
+// RewriteLayout rewrites src's flowchart declaration to use the given
+// layout engine. It is a no-op for any layout other than LayoutELK, and
+// for sources whose first line isn't a "flowchart" declaration; flowcharts
+// declared with the "graph" keyword are left unchanged.
+func RewriteLayout(src, layout string) string {
+	if layout != LayoutELK {
+		return src
+	}
+	lines := strings.SplitN(src, "\n", 2)
+	first := strings.Fields(lines[0])
+	if len(first) == 0 || first[0] != "flowchart" {
+		return src
+	}
+	first[0] = "flowchart-elk"
+	lines[0] = strings.Join(first, " ")
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package mdl
+
+import "testing"
+
+func TestWithLayout(t *testing.T) {
+	cases := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{name: "empty passes through unchanged", layout: "", want: ""},
+		{name: "elk passes through", layout: LayoutELK, want: LayoutELK},
+		{name: "unknown value passes through", layout: "dagre", want: "dagre"},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := WithLayout(c.layout); got != c.want {
+				t.Errorf("WithLayout(%q) = %q, want %q", c.layout, got, c.want)
+			}
+		})
+	}
+}
+
+func TestRewriteLayout(t *testing.T) {
+	cases := []struct {
+		name   string
+		src    string
+		layout string
+		want   string
+	}{
+		{
+			name:   "default layout is a no-op",
+			src:    "flowchart TB\n  a --> b",
+			layout: LayoutDefault,
+			want:   "flowchart TB\n  a --> b",
+		},
+		{
+			name:   "elk rewrites the flowchart declaration",
+			src:    "flowchart TB\n  a --> b",
+			layout: LayoutELK,
+			want:   "flowchart-elk TB\n  a --> b",
+		},
+		{
+			name:   "elk is a no-op for graph declarations",
+			src:    "graph TB\n  a --> b",
+			layout: LayoutELK,
+			want:   "graph TB\n  a --> b",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := RewriteLayout(c.src, c.layout); got != c.want {
+				t.Errorf("RewriteLayout(%q, %q) = %q, want %q", c.src, c.layout, got, c.want)
+			}
+		})
+	}
+}
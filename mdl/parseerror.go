@@ -0,0 +1,29 @@
+package mdl
+
+import "fmt"
+
+// ParseError reports a view whose Mermaid source failed to parse, as
+// surfaced by a Renderer's Parse method.
+type ParseError struct {
+	// ViewKey identifies the view whose source failed to parse.
+	ViewKey string
+	// Line is the 1-based line the error was reported at, 0 if the
+	// backend didn't report one.
+	Line int
+	// Column is the 1-based column the error was reported at, 0 if the
+	// backend didn't report one.
+	Column int
+	// Hash is Mermaid's internal diagram hash, when the backend reports
+	// one.
+	Hash string
+	// Message is the parser's error message.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *ParseError) Error() string {
+	if e.Line > 0 {
+		return fmt.Sprintf("%s:%d:%d: %s", e.ViewKey, e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s: %s", e.ViewKey, e.Message)
+}
@@ -0,0 +1,29 @@
+package mdl
+
+import "testing"
+
+func TestParseErrorError(t *testing.T) {
+	cases := []struct {
+		name string
+		err  *ParseError
+		want string
+	}{
+		{
+			name: "without a line number",
+			err:  &ParseError{ViewKey: "system-context", Message: "unexpected token"},
+			want: "system-context: unexpected token",
+		},
+		{
+			name: "with a line and column",
+			err:  &ParseError{ViewKey: "system-context", Line: 3, Column: 5, Message: "unexpected token"},
+			want: "system-context:3:5: unexpected token",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := c.err.Error(); got != c.want {
+				t.Errorf("Error() = %q, want %q", got, c.want)
+			}
+		})
+	}
+}
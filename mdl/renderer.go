@@ -0,0 +1,34 @@
+package mdl
+
+// Renderer produces a standalone diagram image (SVG, PNG or PDF) from
+// Mermaid source. Implementations drive an external or embedded Mermaid
+// runtime; the split between Parse and Render mirrors the parse/render
+// boundary mermaidAPI exposes in the JS implementation, so callers can
+// validate a diagram before writing any output for it.
+type Renderer interface {
+	// Parse validates Mermaid source. Backends with no parse-only mode
+	// render the source to check it. A non-nil error means the source is
+	// invalid and Render must not be called.
+	Parse(src string) (*ParseResult, error)
+	// Render produces the final image bytes for the given Mermaid source.
+	// format is one of "svg", "png" or "pdf".
+	Render(src, format string) (*RenderResult, error)
+}
+
+// ParseResult reports the outcome of validating Mermaid source.
+type ParseResult struct {
+	// Valid is true when the source parsed without error.
+	Valid bool
+}
+
+// RenderResult holds the output of a successful render.
+type RenderResult struct {
+	// Format is the image format that was rendered ("svg", "png" or "pdf").
+	Format string
+	// Data holds the rendered image bytes.
+	Data []byte
+	// BindFunctions binds interactivity (click handlers, tooltips) to an
+	// already inserted SVG element. It is nil for headless backends,
+	// which have no DOM to bind to.
+	BindFunctions func(element string)
+}
@@ -0,0 +1,26 @@
+package mdl
+
+import (
+	"html/template"
+	"io/fs"
+)
+
+// Theme customizes the appearance of rendered views: the HTML template
+// wrapping a view's diagram, the CSS applied to it, the options passed to
+// mermaidAPI.initialize, and any extra static assets the template
+// references (fonts, images, ...).
+type Theme struct {
+	// Name identifies the theme, e.g. "neutral", "dark" or "forest".
+	Name string
+	// Template renders the page around a view's diagram. It is executed
+	// with a *ViewData-shaped value.
+	Template *template.Template
+	// CSS is inlined into the page's <style> block.
+	CSS string
+	// MermaidInit is merged into the options passed to
+	// mermaidAPI.initialize.
+	MermaidInit map[string]any
+	// Assets holds any additional static files the template references.
+	// The mdl command's render and serve do not copy or serve these files.
+	Assets fs.FS
+}
@@ -0,0 +1,24 @@
+package mdl
+
+// RenderedView is the JSON representation of a generated view, written by
+// gen and read back by the CLI's render and serve commands.
+type RenderedView struct {
+	// Key uniquely identifies the view; it is also used as the stem of
+	// the output file names.
+	Key string
+	// Title of the view.
+	Title string
+	// Description of the view.
+	Description string
+	// Version of the design the view was generated from.
+	Version string
+	// Mermaid is the Mermaid diagram source for the view.
+	Mermaid string
+	// Config holds per-view Mermaid configuration overrides that render
+	// injects into Mermaid's YAML front-matter via WithFrontMatter.
+	Config *MermaidConfig
+	// Layout overrides the flowchart layout engine for this view
+	// (LayoutDefault or LayoutELK). Empty falls back to the --layout
+	// flag passed to render and serve.
+	Layout string
+}